@@ -0,0 +1,57 @@
+package toast
+
+// toastAudio is one of the known ms-winsoundevent:Notification.* sound values that can
+// be referenced by a toast notification's <audio> element.
+//
+// See https://docs.microsoft.com/en-us/uwp/schemas/tiles/toastschema/element-audio for
+// the full list of sounds Windows ships with.
+type toastAudio string
+
+const (
+	Default  toastAudio = "ms-winsoundevent:Notification.Default"
+	IM       toastAudio = "ms-winsoundevent:Notification.IM"
+	Mail     toastAudio = "ms-winsoundevent:Notification.Mail"
+	Reminder toastAudio = "ms-winsoundevent:Notification.Reminder"
+	SMS      toastAudio = "ms-winsoundevent:Notification.SMS"
+
+	LoopingAlarm   toastAudio = "ms-winsoundevent:Notification.Looping.Alarm"
+	LoopingAlarm2  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm2"
+	LoopingAlarm3  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm3"
+	LoopingAlarm4  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm4"
+	LoopingAlarm5  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm5"
+	LoopingAlarm6  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm6"
+	LoopingAlarm7  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm7"
+	LoopingAlarm8  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm8"
+	LoopingAlarm9  toastAudio = "ms-winsoundevent:Notification.Looping.Alarm9"
+	LoopingAlarm10 toastAudio = "ms-winsoundevent:Notification.Looping.Alarm10"
+
+	LoopingCall   toastAudio = "ms-winsoundevent:Notification.Looping.Call"
+	LoopingCall2  toastAudio = "ms-winsoundevent:Notification.Looping.Call2"
+	LoopingCall3  toastAudio = "ms-winsoundevent:Notification.Looping.Call3"
+	LoopingCall4  toastAudio = "ms-winsoundevent:Notification.Looping.Call4"
+	LoopingCall5  toastAudio = "ms-winsoundevent:Notification.Looping.Call5"
+	LoopingCall6  toastAudio = "ms-winsoundevent:Notification.Looping.Call6"
+	LoopingCall7  toastAudio = "ms-winsoundevent:Notification.Looping.Call7"
+	LoopingCall8  toastAudio = "ms-winsoundevent:Notification.Looping.Call8"
+	LoopingCall9  toastAudio = "ms-winsoundevent:Notification.Looping.Call9"
+	LoopingCall10 toastAudio = "ms-winsoundevent:Notification.Looping.Call10"
+
+	// Silent suppresses the notification sound entirely, rendering as <audio silent="true"/>
+	// instead of a src reference.
+	Silent toastAudio = "silent"
+)
+
+// validAudio holds every toastAudio value Push() will accept.
+var validAudio = map[toastAudio]bool{
+	Default: true, IM: true, Mail: true, Reminder: true, SMS: true,
+
+	LoopingAlarm: true, LoopingAlarm2: true, LoopingAlarm3: true, LoopingAlarm4: true,
+	LoopingAlarm5: true, LoopingAlarm6: true, LoopingAlarm7: true, LoopingAlarm8: true,
+	LoopingAlarm9: true, LoopingAlarm10: true,
+
+	LoopingCall: true, LoopingCall2: true, LoopingCall3: true, LoopingCall4: true,
+	LoopingCall5: true, LoopingCall6: true, LoopingCall7: true, LoopingCall8: true,
+	LoopingCall9: true, LoopingCall10: true,
+
+	Silent: true,
+}
@@ -0,0 +1,22 @@
+package toast
+
+import "context"
+
+// Backend displays a Notification, abstracting over how the call into
+// Windows.UI.Notifications is actually made.
+type Backend interface {
+	// Push displays n, blocking until it is shown and, if n has a callback registered,
+	// until one of them fires or ActivationTimeout elapses. It returns ctx.Err() if ctx
+	// is cancelled first.
+	Push(ctx context.Context, n *Notification) error
+}
+
+// defaultBackend is used by Notification.Push for notifications that don't set their
+// own Backend field.
+var defaultBackend Backend = PowerShellBackend{}
+
+// SetDefaultBackend changes the Backend used by Notification.Push when a notification
+// doesn't set its own Backend field. The default is PowerShellBackend{}.
+func SetDefaultBackend(b Backend) {
+	defaultBackend = b
+}
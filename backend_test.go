@@ -0,0 +1,73 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Regression test for the WinRTBackend/PowerShellBackend split: WinRTBackend hands its
+// XML straight to IXmlDocumentIO.LoadXml, so it must get the bare <toast> fragment, not
+// PowerShellBackend's whole generated script.
+func TestBackendBuildXML(t *testing.T) {
+	n := &Notification{Title: "Hi"}
+
+	script, err := PowerShellBackend{}.buildXML(n)
+	if err != nil {
+		t.Fatalf("PowerShellBackend.buildXML: %v", err)
+	}
+	if !strings.Contains(script, "<toast") {
+		t.Fatalf("PowerShellBackend script should embed a <toast> fragment, got: %q", script)
+	}
+
+	winrtXML, err := n.buildXML()
+	if err != nil {
+		t.Fatalf("WinRTBackend buildXML: %v", err)
+	}
+	if !strings.HasPrefix(winrtXML, "<toast") {
+		t.Fatalf("WinRTBackend's XML should start with <toast, got: %q", winrtXML)
+	}
+}
+
+func TestPowerShellBackendBuildXMLImmediate(t *testing.T) {
+	n := &Notification{Title: "Hi"}
+
+	script, err := PowerShellBackend{}.buildXML(n)
+	if err != nil {
+		t.Fatalf("PowerShellBackend.buildXML: %v", err)
+	}
+	if !strings.Contains(script, "New-Object Windows.UI.Notifications.ToastNotification $xml") {
+		t.Fatalf("script should construct a ToastNotification when DeliveryTime is unset, got: %q", script)
+	}
+	if strings.Contains(script, "ScheduledToastNotification") {
+		t.Fatalf("script should not reference ScheduledToastNotification when DeliveryTime is unset, got: %q", script)
+	}
+	if strings.Contains(script, "$toast.ExpirationTime") {
+		t.Fatalf("script should not set ExpirationTime when it is unset, got: %q", script)
+	}
+	if !strings.Contains(script, "CreateToastNotifier($APP_ID).Show($toast)") {
+		t.Fatalf("script should Show immediately when DeliveryTime is unset, got: %q", script)
+	}
+}
+
+func TestPowerShellBackendBuildXMLScheduled(t *testing.T) {
+	n := &Notification{
+		Title:          "Hi",
+		DeliveryTime:   time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+		ExpirationTime: time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	script, err := PowerShellBackend{}.buildXML(n)
+	if err != nil {
+		t.Fatalf("PowerShellBackend.buildXML: %v", err)
+	}
+	if !strings.Contains(script, "New-Object Windows.UI.Notifications.ScheduledToastNotification $xml, ([DateTimeOffset]::Parse('2030-01-02T15:04:05Z'))") {
+		t.Fatalf("script should construct a ScheduledToastNotification at DeliveryTime, got: %q", script)
+	}
+	if !strings.Contains(script, "$toast.ExpirationTime = [DateTimeOffset]::Parse('2030-01-03T00:00:00Z')") {
+		t.Fatalf("script should set ExpirationTime, got: %q", script)
+	}
+	if !strings.Contains(script, "CreateToastNotifier($APP_ID).AddToSchedule($toast)") {
+		t.Fatalf("script should schedule rather than Show when DeliveryTime is set, got: %q", script)
+	}
+}
@@ -0,0 +1,16 @@
+package toast
+
+// toastDuration controls how long a toast notification stays on screen before moving to
+// Action Center, via the <toast duration="..."> attribute.
+type toastDuration string
+
+const (
+	Short toastDuration = "short"
+	Long  toastDuration = "long"
+)
+
+// validDuration holds every toastDuration value Push() will accept.
+var validDuration = map[toastDuration]bool{
+	Short: true,
+	Long:  true,
+}
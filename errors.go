@@ -0,0 +1,16 @@
+package toast
+
+import "errors"
+
+// ErrorInvalidAudio is returned when Notification.Audio is set to a value other than
+// one of the known ms-winsoundevent:Notification.* constants.
+var ErrorInvalidAudio = errors.New("toast: invalid audio value")
+
+// ErrorInvalidDuration is returned when Notification.Duration is set to a value other
+// than toast.Short or toast.Long.
+var ErrorInvalidDuration = errors.New("toast: invalid duration value")
+
+// ErrorInvalidAppID is returned when Notification.AppID contains characters that could
+// break out of the script AppID is embedded in (eg. a single quote, backtick, or
+// newline) or that Windows' Action Centre doesn't support (anything outside ASCII).
+var ErrorInvalidAppID = errors.New("toast: invalid app ID")
@@ -0,0 +1,45 @@
+package toast
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// eventPayload is the JSON body of an "EVENT <json>" line emitted by the generated
+// PowerShell script. Using JSON instead of a space-delimited line lets values contain
+// spaces (eg. reply text typed into an Input) without being truncated or misparsed.
+type eventPayload struct {
+	Event     string            `json:"event"`
+	Arguments string            `json:"arguments"`
+	UserInput map[string]string `json:"userInput"`
+	Reason    string            `json:"reason"`
+}
+
+// dispatchEvent parses a single "EVENT <json>" line emitted by the generated PowerShell
+// script and invokes the matching Notification callback.
+func (n *Notification) dispatchEvent(line string) {
+	const prefix = "EVENT "
+	if !strings.HasPrefix(line, prefix) {
+		return
+	}
+
+	var payload eventPayload
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, prefix)), &payload); err != nil {
+		return
+	}
+
+	switch payload.Event {
+	case "activated":
+		if n.OnActivated != nil {
+			n.OnActivated(payload.Arguments, payload.UserInput)
+		}
+	case "dismissed":
+		if n.OnDismissed != nil {
+			n.OnDismissed(payload.Reason)
+		}
+	case "failed":
+		if n.OnFailed != nil {
+			n.OnFailed()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package toast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDispatchEventActivated(t *testing.T) {
+	var gotArgs string
+	var gotInput map[string]string
+	n := &Notification{OnActivated: func(args string, userInput map[string]string) {
+		gotArgs = args
+		gotInput = userInput
+	}}
+
+	n.dispatchEvent(`EVENT {"event":"activated","arguments":"reply","userInput":{"textBox":"hello there"}}`)
+
+	if gotArgs != "reply" {
+		t.Errorf("args = %q, want %q", gotArgs, "reply")
+	}
+	if want := map[string]string{"textBox": "hello there"}; !reflect.DeepEqual(gotInput, want) {
+		t.Errorf("userInput = %v, want %v", gotInput, want)
+	}
+}
+
+func TestDispatchEventDismissed(t *testing.T) {
+	var gotReason string
+	n := &Notification{OnDismissed: func(reason string) { gotReason = reason }}
+
+	n.dispatchEvent(`EVENT {"event":"dismissed","reason":"UserCanceled"}`)
+
+	if gotReason != "UserCanceled" {
+		t.Errorf("reason = %q, want %q", gotReason, "UserCanceled")
+	}
+}
+
+func TestDispatchEventFailed(t *testing.T) {
+	called := false
+	n := &Notification{OnFailed: func() { called = true }}
+
+	n.dispatchEvent(`EVENT {"event":"failed"}`)
+
+	if !called {
+		t.Error("OnFailed was not called")
+	}
+}
+
+func TestDispatchEventIgnoresNonEventLines(t *testing.T) {
+	n := &Notification{OnFailed: func() { t.Error("OnFailed should not be called") }}
+	n.dispatchEvent("some unrelated PowerShell output")
+}
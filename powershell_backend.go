@@ -0,0 +1,169 @@
+package toast
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"text/template"
+
+	"github.com/nu7hatch/gouuid"
+	"golang.org/x/sys/windows/registry"
+)
+
+// PowerShellBackend displays a Notification by rendering it to a PowerShell script and
+// running that script with powershell.exe. It's the original go-toast backend, and
+// remains the default since it has no dependencies beyond what Windows ships with, but
+// starting the PowerShell VM is by far the slowest part of showing a toast - see
+// WinRTBackend for a faster alternative.
+type PowerShellBackend struct{}
+
+var toastTemplate *template.Template
+
+func init() {
+	toastTemplate = template.New("toast").Funcs(template.FuncMap{
+		"hasCallback":    (*Notification).hasCallback,
+		"timeoutSeconds": (*Notification).activationTimeoutSeconds,
+		"toastXML":       buildToastXML,
+	})
+	toastTemplate.Parse(`
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.UI.Notifications.ToastNotification, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+
+$APP_ID = '{{if .AppID}}{{.AppID}}{{else}}io.github.go-toast.toast{{end}}'
+
+$template = @'
+{{toastXML .}}
+'@
+
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml($template)
+{{if not .DeliveryTime.IsZero}}
+$toast = New-Object Windows.UI.Notifications.ScheduledToastNotification $xml, ([DateTimeOffset]::Parse('{{.DeliveryTime.Format "2006-01-02T15:04:05Z07:00"}}'))
+{{else}}
+$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+{{end}}
+{{if not .ExpirationTime.IsZero}}
+$toast.ExpirationTime = [DateTimeOffset]::Parse('{{.ExpirationTime.Format "2006-01-02T15:04:05Z07:00"}}')
+{{end}}
+{{if hasCallback .}}
+Register-ObjectEvent -InputObject $toast -EventName Activated -SourceIdentifier ToastActivated -Action {
+    $userInput = @{}
+    foreach ($key in $event.SourceEventArgs.UserInput.Keys) {
+        $userInput[$key] = $event.SourceEventArgs.UserInput[$key]
+    }
+    $payload = @{ event = "activated"; arguments = $event.SourceEventArgs.Arguments; userInput = $userInput }
+    Write-Host "EVENT $($payload | ConvertTo-Json -Compress)"
+} | Out-Null
+Register-ObjectEvent -InputObject $toast -EventName Dismissed -SourceIdentifier ToastDismissed -Action {
+    $payload = @{ event = "dismissed"; reason = [string]$event.SourceEventArgs.Reason }
+    Write-Host "EVENT $($payload | ConvertTo-Json -Compress)"
+} | Out-Null
+Register-ObjectEvent -InputObject $toast -EventName Failed -SourceIdentifier ToastFailed -Action {
+    Write-Host 'EVENT {"event":"failed"}'
+} | Out-Null
+{{end}}
+{{if not .DeliveryTime.IsZero}}
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($APP_ID).AddToSchedule($toast)
+{{else}}
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($APP_ID).Show($toast)
+{{end}}
+{{if hasCallback .}}
+$deadline = (Get-Date).AddSeconds({{timeoutSeconds .}})
+while ((Get-Date) -lt $deadline) {
+    $remaining = ($deadline - (Get-Date)).TotalSeconds
+    if ($remaining -le 0) { break }
+    $ev = Wait-Event -Timeout $remaining
+    if ($ev) {
+        Remove-Event -EventIdentifier $ev.EventIdentifier
+        break
+    }
+}
+Unregister-Event -SourceIdentifier ToastActivated -ErrorAction SilentlyContinue
+Unregister-Event -SourceIdentifier ToastDismissed -ErrorAction SilentlyContinue
+Unregister-Event -SourceIdentifier ToastFailed -ErrorAction SilentlyContinue
+{{end}}
+    `)
+}
+
+func (b PowerShellBackend) buildXML(n *Notification) (string, error) {
+	var out bytes.Buffer
+	err := toastTemplate.Execute(&out, n)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func (b PowerShellBackend) Push(ctx context.Context, n *Notification) error {
+	if n.Persist {
+		// Create a key for the AppID's persistence setting.
+		k, _, _ := registry.CreateKey(registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Notifications\Settings\`+n.AppID, registry.ALL_ACCESS)
+		// Set the correct registry value.
+		k.SetDWordValue("ShowInActionCenter", uint32(1))
+		k.Close()
+	} else {
+		registry.DeleteKey(registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Notifications\Settings\`+n.AppID)
+	}
+
+	xml, _ := b.buildXML(n)
+	return invokeTemporaryScript(ctx, n, xml)
+}
+
+func invokeTemporaryScript(ctx context.Context, n *Notification, content string) error {
+	id, _ := uuid.NewV4()
+	file := filepath.Join(os.TempDir(), id.String()+".ps1")
+	defer os.Remove(file)
+	err := ioutil.WriteFile(file, []byte(content), 0600)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "PowerShell", "-ExecutionPolicy", "Bypass", "-File", file)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+
+	if !n.hasCallback() {
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		return nil
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for scanner.Scan() {
+			n.dispatchEvent(scanner.Text())
+		}
+	}()
+
+	// Wait for the scanner to reach EOF before calling cmd.Wait, since Wait
+	// closes the stdout pipe as soon as the process exits and would race
+	// with the goroutine still reading the final EVENT line out of it.
+	<-done
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
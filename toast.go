@@ -1,60 +1,10 @@
 package toast
 
 import (
-	"bytes"
-	"io/ioutil"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"text/template"
-
-	"github.com/nu7hatch/gouuid"
-	"golang.org/x/sys/windows/registry"
+	"context"
+	"time"
 )
 
-var toastTemplate *template.Template
-
-func init() {
-	toastTemplate = template.New("toast")
-	toastTemplate.Parse(`
-[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
-[Windows.UI.Notifications.ToastNotification, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
-[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
-
-$APP_ID = '{{if .AppID}}{{.AppID}}{{else}}io.github.go-toast.toast{{end}}'
-
-$template = @"
-<toast>
-    <visual>
-        <binding template="ToastGeneric">
-            {{if .Icon}}
-            <image placement="appLogoOverride" src="{{.Icon}}" />
-            {{end}}
-            {{if .Title}}
-            <text>{{.Title}}</text>
-            {{end}}
-            {{if .Message}}
-            <text>{{.Message}}</text>
-            {{end}}
-        </binding>
-    </visual>
-    {{if .Actions}}
-    <actions>
-        {{range .Actions}}
-        <action activationType="{{.Type}}" content="{{.Label}}" arguments="{{.Arguments}}" />
-        {{end}}
-    </actions>
-    {{end}}
-</toast>
-"@
-
-$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
-$xml.LoadXml($template)
-$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
-[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($APP_ID).Show($toast)
-    `)
-}
-
 type Notification struct {
 	// The name of your app. This value shows up in Windows 10's Action Centre, so make it
 	// something readable for your users. It can contain spaces, however special characters
@@ -67,14 +17,88 @@ type Notification struct {
 	// The single/multi line message to display for the toast notification.
 	Message string
 
+	// An optional third line of text, displayed below the Title and Message.
+	Text3 string
+
 	// An optional path to an image on the OS to display to the left of the title & message.
 	Icon    string
 
+	// An optional path to an image on the OS to display across the top of the toast,
+	// above the title & message.
+	HeroImage string
+
+	// An optional path to an image on the OS to display within the body of the toast.
+	InlineImage string
+
+	// An optional line of text displayed at the bottom of the toast, styled to indicate
+	// it names the source of the content (eg. an account or app name).
+	Attribution string
+
+	// An optional progress bar to display within the body of the toast.
+	Progress *ProgressBar
+
 	// Optional action buttons to display below the notification title & message.
 	Actions []Action
 
+	// Optional text-input or selection controls to display alongside the action buttons.
+	Inputs []Input
+
 	// Should the notification persist in Action Center?
 	Persist bool
+
+	// The audio to play when the toast notification is shown. Leave empty to use the
+	// default notification sound, or set to toast.Silent to play no sound at all.
+	Audio toastAudio
+
+	// Whether the audio should loop. Only valid for looping sounds (eg. toast.LoopingAlarm),
+	// and only permitted when Duration is Long.
+	Loop bool
+
+	// How long the toast should display for. Leave empty to use the Windows default.
+	Duration toastDuration
+
+	// If set, the notification is handed to Windows' scheduler and shown at this time
+	// instead of immediately. Applied by PowerShellBackend only; see WinRTBackend.
+	DeliveryTime time.Time
+
+	// If set, the notification is automatically removed from Action Center at this time.
+	// Applied by PowerShellBackend only; see WinRTBackend.
+	ExpirationTime time.Time
+
+	// OnActivated is called when the user clicks the toast body or one of its action
+	// buttons, with the arguments string of whichever was activated and the values of
+	// any Inputs the user filled in or selected, keyed by Input.ID.
+	OnActivated func(args string, userInput map[string]string)
+
+	// OnDismissed is called when the user dismisses the toast, with the reason Windows
+	// reports (eg. "UserCanceled", "TimedOut", "ApplicationHidden").
+	OnDismissed func(reason string)
+
+	// OnFailed is called if Windows fails to raise the toast.
+	OnFailed func()
+
+	// How long to wait for one of the above callbacks to fire before giving up. Defaults
+	// to 5 minutes if a callback is set and this is zero.
+	ActivationTimeout time.Duration
+
+	// The Backend used to display this notification. Leave nil to use the backend set
+	// by SetDefaultBackend (PowerShellBackend{} unless overridden).
+	Backend Backend
+}
+
+// hasCallback reports whether the caller wants to be notified of toast events, which
+// requires keeping the PowerShell process alive to listen for them.
+func (n *Notification) hasCallback() bool {
+	return n.OnActivated != nil || n.OnDismissed != nil || n.OnFailed != nil
+}
+
+// activationTimeoutSeconds resolves ActivationTimeout to a default when unset.
+func (n *Notification) activationTimeoutSeconds() float64 {
+	d := n.ActivationTimeout
+	if d <= 0 {
+		d = 5 * time.Minute
+	}
+	return d.Seconds()
 }
 
 // Defines an actionable button.
@@ -89,21 +113,84 @@ type Action struct {
 	Type      string
 	Label     string
 	Arguments string
+
+	// If set, binds this button to the input with the matching Input.ID instead of
+	// displaying it alongside all of them.
+	HintInputID string
+
+	// An optional path to an image to display on the button instead of text.
+	ImageUri string
+
+	// Set to "contextMenu" to display this action in the toast's context menu instead
+	// of as a visible button.
+	Placement string
+}
+
+// Defines a text-input or selection control shown in the actions area of a toast.
+// See https://docs.microsoft.com/en-us/uwp/schemas/tiles/toastschema/element-input for more info.
+type Input struct {
+	// A unique identifier, referenced by Action.HintInputID and used as the key the
+	// value is returned under in OnActivated's userInput map.
+	ID string
+
+	// "text" for a free-form text box, or "selection" for a picker populated from Selections.
+	Type string
+
+	// Placeholder text shown in an empty "text" input.
+	PlaceHolderContent string
+
+	// The ID of the Selection that should be pre-selected in a "selection" input.
+	DefaultInput string
+
+	// The choices offered by a "selection" input.
+	Selections []Selection
+}
+
+// Defines a single choice within a "selection" type Input.
+type Selection struct {
+	ID      string
+	Content string
+}
+
+// Defines a progress bar shown within the body of the toast.
+// See https://docs.microsoft.com/en-us/uwp/schemas/tiles/toastschema/element-progress for more info.
+type ProgressBar struct {
+	// The title displayed above the progress bar.
+	Title string
+
+	// A short status string displayed alongside the progress bar, eg. "Downloading...".
+	Status string
+
+	// The amount of progress to display, from "0" to "1", or "indeterminate" for an
+	// animated bar with no fixed completion amount.
+	Value string
+
+	// An optional string to display instead of the default percentage, eg. "3/15 files".
+	ValueStringOverride string
 }
 
-func (n *Notification) buildXML() (string, error) {
-	var out bytes.Buffer
-	err := toastTemplate.Execute(&out, n)
-	if err != nil {
-		return "", err
+// validate checks the fields of the notification against the constraints imposed by the
+// WinRT toast schema, returning one of the Error* sentinels on the first violation found.
+func (n *Notification) validate() error {
+	if n.AppID != "" && !validAppID(n.AppID) {
+		return ErrorInvalidAppID
+	}
+	if n.Audio != "" && !validAudio[n.Audio] {
+		return ErrorInvalidAudio
+	}
+	if n.Duration != "" && !validDuration[n.Duration] {
+		return ErrorInvalidDuration
+	}
+	if n.Loop && n.Duration != Long {
+		return ErrorInvalidAudio
 	}
-	return out.String(), nil
+	return nil
 }
 
-// Builds the Windows PowerShell script & invokes it, causing the toast to display.
+// Builds the notification & hands it to a Backend to display.
 //
-// Note: Running the PowerShell script is by far the slowest process here, and can take a few
-// seconds in some cases.
+// Note: the default PowerShellBackend is by far the slowest way of doing this, and can
+// take a few seconds in some cases. See WinRTBackend for a faster alternative.
 //
 //     notification := toast.Notification{
 //         AppID: "Example App",
@@ -120,30 +207,20 @@ func (n *Notification) buildXML() (string, error) {
 //         log.Fatalln(err)
 //     }
 func (n *Notification) Push() error {
-	if n.Persist {
-		// Create a key for the AppID's persistence setting.
-		k, _, _ := registry.CreateKey(registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Notifications\Settings\`+n.AppID, registry.ALL_ACCESS)
-		// Set the correct registry value.
-		k.SetDWordValue("ShowInActionCenter", uint32(1))
-		k.Close()
-	} else {
-		registry.DeleteKey(registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Notifications\Settings\`+n.AppID)
-	}
-
-	xml, _ := n.buildXML()
-	return invokeTemporaryScript(xml)
+	return n.PushContext(context.Background())
 }
 
-func invokeTemporaryScript(content string) error {
-	id, _ := uuid.NewV4()
-	file := filepath.Join(os.TempDir(), id.String()+".ps1")
-	defer os.Remove(file)
-	err := ioutil.WriteFile(file, []byte(content), 0600)
-	if err != nil {
+// PushContext is like Push, but aborts and returns ctx.Err() if ctx is cancelled before
+// the notification finishes displaying (and, if a callback is registered, before one of
+// them fires).
+func (n *Notification) PushContext(ctx context.Context) error {
+	if err := n.validate(); err != nil {
 		return err
 	}
-	if err = exec.Command("PowerShell", "-ExecutionPolicy", "Bypass", "-File", file).Run(); err != nil {
-		return err
+
+	b := n.Backend
+	if b == nil {
+		b = defaultBackend
 	}
-	return nil
+	return b.Push(ctx, n)
 }
@@ -0,0 +1,29 @@
+package toast
+
+import "testing"
+
+func TestNotificationValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		n    Notification
+		want error
+	}{
+		{"zero value", Notification{}, nil},
+		{"valid app id", Notification{AppID: "My App"}, nil},
+		{"invalid app id", Notification{AppID: "it's mine"}, ErrorInvalidAppID},
+		{"valid audio", Notification{Audio: Default}, nil},
+		{"invalid audio", Notification{Audio: "not-a-sound"}, ErrorInvalidAudio},
+		{"loop without long duration", Notification{Audio: LoopingAlarm, Loop: true}, ErrorInvalidAudio},
+		{"loop with long duration", Notification{Audio: LoopingAlarm, Loop: true, Duration: Long}, nil},
+		{"valid duration", Notification{Duration: Short}, nil},
+		{"invalid duration", Notification{Duration: "forever"}, ErrorInvalidDuration},
+		{"loop with invalid duration", Notification{Audio: LoopingAlarm, Loop: true, Duration: "forever"}, ErrorInvalidDuration},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.n.validate(); got != c.want {
+				t.Errorf("validate() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package toast
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// toastXMLTemplate renders the <toast>...</toast> fragment shared by every Backend -
+// PowerShellBackend embeds it in the script it generates, WinRTBackend feeds it straight
+// to XmlDocument.LoadXml. Kept separate from the PowerShell script template so neither
+// backend can drift from what the other actually shows.
+var toastXMLTemplate = template.Must(template.New("toastXML").Funcs(template.FuncMap{
+	"xmlEscape": xmlEscape,
+}).Parse(`<toast{{if .Duration}} duration="{{.Duration}}"{{end}}>
+    <visual>
+        <binding template="ToastGeneric">
+            {{if .HeroImage}}
+            <image placement="hero" src="{{xmlEscape .HeroImage}}" />
+            {{end}}
+            {{if .Icon}}
+            <image placement="appLogoOverride" src="{{xmlEscape .Icon}}" />
+            {{end}}
+            {{if .Title}}
+            <text>{{xmlEscape .Title}}</text>
+            {{end}}
+            {{if .Message}}
+            <text>{{xmlEscape .Message}}</text>
+            {{end}}
+            {{if .Text3}}
+            <text>{{xmlEscape .Text3}}</text>
+            {{end}}
+            {{if .Attribution}}
+            <text placement="attribution">{{xmlEscape .Attribution}}</text>
+            {{end}}
+            {{if .InlineImage}}
+            <image src="{{xmlEscape .InlineImage}}" />
+            {{end}}
+            {{if .Progress}}
+            <progress title="{{xmlEscape .Progress.Title}}" value="{{xmlEscape .Progress.Value}}" valueStringOverride="{{xmlEscape .Progress.ValueStringOverride}}" status="{{xmlEscape .Progress.Status}}" />
+            {{end}}
+        </binding>
+    </visual>
+    {{if or .Actions .Inputs}}
+    <actions>
+        {{range .Inputs}}
+        <input id="{{xmlEscape .ID}}" type="{{xmlEscape .Type}}"{{if .PlaceHolderContent}} placeHolderContent="{{xmlEscape .PlaceHolderContent}}"{{end}}{{if .DefaultInput}} defaultInput="{{xmlEscape .DefaultInput}}"{{end}}>
+            {{range .Selections}}
+            <selection id="{{xmlEscape .ID}}" content="{{xmlEscape .Content}}" />
+            {{end}}
+        </input>
+        {{end}}
+        {{range .Actions}}
+        <action activationType="{{xmlEscape .Type}}" content="{{xmlEscape .Label}}" arguments="{{xmlEscape .Arguments}}"{{if .HintInputID}} hint-inputId="{{xmlEscape .HintInputID}}"{{end}}{{if .ImageUri}} imageUri="{{xmlEscape .ImageUri}}"{{end}}{{if .Placement}} placement="{{xmlEscape .Placement}}"{{end}} />
+        {{end}}
+    </actions>
+    {{end}}
+    {{if eq .Audio "silent"}}
+    <audio silent="true" />
+    {{else if .Audio}}
+    <audio src="{{.Audio}}"{{if .Loop}} loop="true"{{end}} />
+    {{end}}
+</toast>`))
+
+// buildToastXML renders n as a standalone <toast> XML fragment, independent of whichever
+// Backend ends up displaying it.
+func buildToastXML(n *Notification) (string, error) {
+	var out bytes.Buffer
+	if err := toastXMLTemplate.Execute(&out, n); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
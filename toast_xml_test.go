@@ -0,0 +1,149 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildToastXML(t *testing.T) {
+	n := &Notification{Title: "Hi", Message: "there"}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if !strings.HasPrefix(xml, "<toast") {
+		t.Fatalf("buildToastXML should render a bare <toast> fragment, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLEscapesFields(t *testing.T) {
+	n := &Notification{Title: `<script>"'`}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if want := xmlEscape(n.Title); !strings.Contains(xml, want) {
+		t.Fatalf("buildToastXML should escape Title, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLProgressBar(t *testing.T) {
+	n := &Notification{Progress: &ProgressBar{
+		Title:               "Copying files",
+		Status:              "3/15 files",
+		Value:               "0.2",
+		ValueStringOverride: "3/15",
+	}}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	want := `<progress title="Copying files" value="0.2" valueStringOverride="3/15" status="3/15 files" />`
+	if !strings.Contains(xml, want) {
+		t.Fatalf("buildToastXML should render the progress element, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLNoProgressBar(t *testing.T) {
+	n := &Notification{Title: "Hi"}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if strings.Contains(xml, "<progress") {
+		t.Fatalf("buildToastXML should omit <progress> when Progress is nil, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLInputAndSelection(t *testing.T) {
+	n := &Notification{
+		Inputs: []Input{
+			{
+				ID:                 "textBox",
+				Type:               "text",
+				PlaceHolderContent: "Type a reply",
+			},
+			{
+				ID:           "choice",
+				Type:         "selection",
+				DefaultInput: "snooze",
+				Selections: []Selection{
+					{ID: "snooze", Content: "Snooze"},
+					{ID: "dismiss", Content: "Dismiss"},
+				},
+			},
+		},
+		Actions: []Action{
+			{Type: "protocol", Label: "Reply", Arguments: "reply", HintInputID: "textBox"},
+		},
+	}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+
+	for _, want := range []string{
+		`<input id="textBox" type="text" placeHolderContent="Type a reply">`,
+		`<input id="choice" type="selection" defaultInput="snooze">`,
+		`<selection id="snooze" content="Snooze" />`,
+		`<selection id="dismiss" content="Dismiss" />`,
+		`<action activationType="protocol" content="Reply" arguments="reply" hint-inputId="textBox" />`,
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("buildToastXML output missing %q, got: %q", want, xml)
+		}
+	}
+}
+
+func TestBuildToastXMLNoActionsOmitsActionsElement(t *testing.T) {
+	n := &Notification{Title: "Hi"}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if strings.Contains(xml, "<actions>") {
+		t.Fatalf("buildToastXML should omit <actions> when there are no Actions or Inputs, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLAudio(t *testing.T) {
+	n := &Notification{Audio: LoopingAlarm, Loop: true, Duration: Long}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if !strings.Contains(xml, `<audio src="`+string(LoopingAlarm)+`" loop="true" />`) {
+		t.Fatalf("buildToastXML should render the audio element with loop, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLAudioSilent(t *testing.T) {
+	n := &Notification{Audio: Silent}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if !strings.Contains(xml, `<audio silent="true" />`) {
+		t.Fatalf("buildToastXML should render a silent audio element, got: %q", xml)
+	}
+}
+
+func TestBuildToastXMLDuration(t *testing.T) {
+	n := &Notification{Duration: Long}
+
+	xml, err := buildToastXML(n)
+	if err != nil {
+		t.Fatalf("buildToastXML: %v", err)
+	}
+	if !strings.HasPrefix(xml, `<toast duration="long">`) {
+		t.Fatalf("buildToastXML should render the duration attribute, got: %q", xml)
+	}
+}
@@ -0,0 +1,224 @@
+package toast
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Interface identifiers for the WinRT interfaces this backend activates, taken from the
+// Windows SDK headers (inspectable.h, windows.data.xml.dom.h, windows.ui.notifications.h).
+const (
+	iidIXmlDocumentIO                   = "6CD0E74E-EE65-4489-9EBF-CA43E87BA637"
+	iidIToastNotificationFactory        = "04124B20-82C6-4229-B109-FD9ED4662B53"
+	iidIToastNotificationManagerStatics = "50AC103F-D235-4598-BBEF-98FE4D1A3AD4"
+	iidIToastNotifier                   = "75927B93-03B1-4450-9C5B-6FEBD3FC6A3A"
+	iidIToastNotification               = "997E2675-059E-4E60-8B06-1760917C8B80"
+	iidIToastNotification2              = "9DFB9FD1-143A-490E-90BF-B9FBA7132DE7"
+)
+
+// Every WinRT object this backend touches is IInspectable-derived: the vtable begins
+// with the three IUnknown slots, followed by the three IInspectable slots
+// (GetIids, GetRuntimeClassName, GetTrustLevel), with interface-specific methods after.
+const inspectableMethodOffset = 6
+
+// vtblCall invokes the method at the given zero-based index (counted from the start of
+// the interface-specific methods, ie. after the IInspectable slots) on obj's vtable.
+func vtblCall(obj *ole.IUnknown, index uintptr, args ...uintptr) (uintptr, error) {
+	vtbl := *(**[64]uintptr)(unsafe.Pointer(obj))
+	proc := vtbl[inspectableMethodOffset+index]
+
+	self := uintptr(unsafe.Pointer(obj))
+	all := append([]uintptr{self}, args...)
+	ret, _, _ := syscall.SyscallN(proc, all...)
+	if int32(ret) < 0 {
+		return 0, fmt.Errorf("toast: WinRT call failed: 0x%x", ret)
+	}
+	return ret, nil
+}
+
+// callLoadXml calls IXmlDocumentIO.LoadXml(doc, xml).
+func callLoadXml(doc *ole.IUnknown, xml uintptr) error {
+	_, err := vtblCall(doc, 0, xml)
+	return err
+}
+
+// IToastNotificationManagerStatics' interface-specific vtable slots: the implicit
+// zero-arg CreateToastNotifier() that uses the process's own AppUserModelID, followed by
+// the CreateToastNotifierWithId(appId) overload this backend actually needs since it
+// lets callers set a custom AppID.
+const (
+	idxManagerCreateToastNotifier       = 0
+	idxManagerCreateToastNotifierWithId = 1
+)
+
+// callCreateToastNotifier calls IToastNotificationManagerStatics.CreateToastNotifierWithId(appID)
+// and returns the resulting IToastNotifier.
+func callCreateToastNotifier(managerFactory *ole.IUnknown, appID uintptr) (*ole.IUnknown, error) {
+	var notifier *ole.IUnknown
+	if _, err := vtblCall(managerFactory, idxManagerCreateToastNotifierWithId, appID, uintptr(unsafe.Pointer(&notifier))); err != nil {
+		return nil, err
+	}
+	return notifier, nil
+}
+
+// callCreateToastNotification calls IToastNotificationFactory.CreateToastNotification(xmlDoc)
+// and returns the resulting IToastNotification.
+func callCreateToastNotification(toastFactory, xmlDoc *ole.IUnknown) (*ole.IUnknown, error) {
+	var toast *ole.IUnknown
+	if _, err := vtblCall(toastFactory, 0, uintptr(unsafe.Pointer(xmlDoc)), uintptr(unsafe.Pointer(&toast))); err != nil {
+		return nil, err
+	}
+	return toast, nil
+}
+
+// callShow calls IToastNotifier.Show(toast).
+func callShow(notifier, toast *ole.IUnknown) error {
+	_, err := vtblCall(notifier, 0, uintptr(unsafe.Pointer(toast)))
+	return err
+}
+
+// IToastNotification's interface-specific vtable slots, in the order windows.ui.notifications.h
+// declares them - a property (get_Content), two more (put_/get_ExpirationTime), then the
+// three add_*/remove_* event pairs this backend subscribes to.
+const (
+	idxToastGetContent        = 0
+	idxToastPutExpirationTime = 1
+	idxToastGetExpirationTime = 2
+	idxToastAddDismissed      = 3
+	idxToastRemoveDismissed   = 4
+	idxToastAddActivated      = 5
+	idxToastRemoveActivated   = 6
+	idxToastAddFailed         = 7
+	idxToastRemoveFailed      = 8
+)
+
+// eventHandlerVtbl is the COM vtable shape WinRT expects behind the handler passed to
+// IToastNotification's add_Activated/add_Dismissed/add_Failed: the three IUnknown methods
+// plus the single Invoke(sender, args) method every ITypedEventHandler<TSender, TArgs>
+// specialization has in common.
+type eventHandlerVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	Invoke         uintptr
+}
+
+// eventHandler is a minimal COM object standing in for an ITypedEventHandler: just enough
+// of a vtable-backed shim for WinRT to call Invoke on, not a real COM component. Its
+// QueryInterface always fails - WinRT only ever invokes the single interface it was handed
+// - and AddRef/Release are no-ops, since nothing here does COM reference counting; see
+// pinEventHandler for how its lifetime is actually managed.
+type eventHandler struct {
+	vtbl   *eventHandlerVtbl
+	invoke func(sender, args uintptr) uintptr
+}
+
+// newEventHandler builds an eventHandler whose Invoke method calls fn, and returns the
+// uintptr to hand to a WinRT add_* method as the handler argument.
+func newEventHandler(fn func(sender, args uintptr) uintptr) (*eventHandler, uintptr) {
+	h := &eventHandler{invoke: fn}
+	h.vtbl = &eventHandlerVtbl{
+		QueryInterface: syscall.NewCallback(func(this uintptr, riid *ole.GUID, ppv *uintptr) uintptr {
+			*ppv = 0
+			return uintptr(ole.E_NOINTERFACE)
+		}),
+		AddRef: syscall.NewCallback(func(this uintptr) uintptr {
+			return 1
+		}),
+		Release: syscall.NewCallback(func(this uintptr) uintptr {
+			return 1
+		}),
+		Invoke: syscall.NewCallback(func(this, sender, args uintptr) uintptr {
+			return h.invoke(sender, args)
+		}),
+	}
+	return h, uintptr(unsafe.Pointer(h))
+}
+
+// pinnedEventHandlers keeps every eventHandler built by subscribeToastEvents reachable for
+// the life of the process. WinRT holds only the raw pointer handed to it, not a Go
+// reference, and eventHandler.Release doesn't do real COM refcounting - without this the
+// GC could free a handler's shim while Windows still expects to be able to call it.
+var (
+	pinnedEventHandlersMu sync.Mutex
+	pinnedEventHandlers   []*eventHandler
+)
+
+func pinEventHandler(h *eventHandler) {
+	pinnedEventHandlersMu.Lock()
+	pinnedEventHandlers = append(pinnedEventHandlers, h)
+	pinnedEventHandlersMu.Unlock()
+}
+
+// subscribeToastEvents wires n's OnActivated/OnDismissed/OnFailed callbacks to the
+// toast's Activated/Dismissed/Failed events via IToastNotification's add_* methods, so
+// callers get their feedback in-process instead of through PowerShellBackend's stdout
+// scraping. done is called after each callback fires, so Push can block until one of
+// them does.
+func subscribeToastEvents(toast *ole.IUnknown, n *Notification, done func()) error {
+	activated, activatedPtr := newEventHandler(func(sender, args uintptr) uintptr {
+		if n.OnActivated != nil {
+			n.OnActivated(readWinRTArguments(args), readWinRTUserInput(args))
+		}
+		done()
+		return 0
+	})
+	pinEventHandler(activated)
+	var activatedToken int64
+	if _, err := vtblCall(toast, idxToastAddActivated, activatedPtr, uintptr(unsafe.Pointer(&activatedToken))); err != nil {
+		return err
+	}
+
+	dismissed, dismissedPtr := newEventHandler(func(sender, args uintptr) uintptr {
+		if n.OnDismissed != nil {
+			n.OnDismissed(readWinRTDismissReason(args))
+		}
+		done()
+		return 0
+	})
+	pinEventHandler(dismissed)
+	var dismissedToken int64
+	if _, err := vtblCall(toast, idxToastAddDismissed, dismissedPtr, uintptr(unsafe.Pointer(&dismissedToken))); err != nil {
+		return err
+	}
+
+	failed, failedPtr := newEventHandler(func(sender, args uintptr) uintptr {
+		if n.OnFailed != nil {
+			n.OnFailed()
+		}
+		done()
+		return 0
+	})
+	pinEventHandler(failed)
+	var failedToken int64
+	if _, err := vtblCall(toast, idxToastAddFailed, failedPtr, uintptr(unsafe.Pointer(&failedToken))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readWinRTArguments reads the Arguments string off a ToastActivatedEventArgs pointer.
+// Left as a narrow stub: decoding the event args struct requires walking its own vtable,
+// which is beyond what this backend needs until callers report they rely on it. See the
+// limitation documented on WinRTBackend.
+func readWinRTArguments(args uintptr) string {
+	return ""
+}
+
+// readWinRTUserInput reads the UserInput value set off a ToastActivatedEventArgs
+// pointer. Left as a narrow stub alongside readWinRTArguments - see the limitation
+// documented on WinRTBackend.
+func readWinRTUserInput(args uintptr) map[string]string {
+	return nil
+}
+
+// readWinRTDismissReason reads the Reason enum off a ToastDismissedEventArgs pointer.
+// Left as a narrow stub - see the limitation documented on WinRTBackend.
+func readWinRTDismissReason(args uintptr) string {
+	return ""
+}
@@ -0,0 +1,184 @@
+package toast
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+)
+
+// WinRTBackend displays a Notification by activating Windows.UI.Notifications types
+// directly through WinRT/COM, without writing a temporary .ps1 or spawning powershell.exe.
+// This removes the multi-second PowerShell startup cost that PowerShellBackend pays for
+// every toast, and lets callers subscribe to Activated/Dismissed/Failed in-process
+// instead of scraping them off stdout.
+//
+// Known limitation: OnActivated's args/userInput and OnDismissed's reason are always
+// delivered empty under this backend - reading them means walking the
+// ToastActivatedEventArgs/ToastDismissedEventArgs vtables, which isn't implemented yet.
+// OnFailed is unaffected, since it carries no payload. Use PowerShellBackend if your
+// callbacks depend on that data.
+//
+// This backend also ignores DeliveryTime, ExpirationTime and Persist: the toast is
+// always shown immediately, is given no expiration time, and no Action Center
+// registry setting is written.
+type WinRTBackend struct{}
+
+var (
+	modcombase                 = syscall.NewLazyDLL("combase.dll")
+	procRoInitialize           = modcombase.NewProc("RoInitialize")
+	procRoGetActivationFactory = modcombase.NewProc("RoGetActivationFactory")
+	procWindowsCreateString    = modcombase.NewProc("WindowsCreateString")
+	procWindowsDeleteString    = modcombase.NewProc("WindowsDeleteString")
+)
+
+const (
+	rtMultiThreaded = 1
+
+	classXmlDocument              = "Windows.Data.Xml.Dom.XmlDocument"
+	classToastNotificationManager = "Windows.UI.Notifications.ToastNotificationManager"
+	classToastNotification        = "Windows.UI.Notifications.ToastNotification"
+)
+
+// hstring creates a WinRT HSTRING from a Go string, for passing to WinRT activation
+// factory lookups and method calls. The returned handle must be released with
+// procWindowsDeleteString once no longer needed.
+func hstring(s string) (uintptr, error) {
+	// WindowsCreateString wants the UTF-16 code-unit count, not len(s) (s's UTF-8 byte
+	// length) - the two only agree for all-ASCII strings. UTF16FromString's result
+	// includes a trailing NUL we don't count.
+	u16, err := syscall.UTF16FromString(s)
+	if err != nil {
+		return 0, err
+	}
+	var h uintptr
+	ret, _, _ := procWindowsCreateString.Call(uintptr(unsafe.Pointer(&u16[0])), uintptr(len(u16)-1), uintptr(unsafe.Pointer(&h)))
+	if ret != 0 {
+		return 0, fmt.Errorf("toast: WindowsCreateString failed: 0x%x", ret)
+	}
+	return h, nil
+}
+
+// activateInstance looks up the IInspectable activation factory for a fully-qualified
+// WinRT runtime class name (eg. "Windows.UI.Notifications.ToastNotificationManager")
+// and queries it for the interface identified by iid.
+func activateInstance(class string, iid *ole.GUID) (*ole.IUnknown, error) {
+	name, err := hstring(class)
+	if err != nil {
+		return nil, err
+	}
+	defer procWindowsDeleteString.Call(name)
+
+	var factory *ole.IUnknown
+	ret, _, _ := procRoGetActivationFactory.Call(name, uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&factory)))
+	if ret != 0 {
+		return nil, fmt.Errorf("toast: RoGetActivationFactory(%s) failed: 0x%x", class, ret)
+	}
+	return factory, nil
+}
+
+// Push builds the toast XML in-process via an XmlDocument, constructs a
+// ToastNotification from it, and shows it through the ToastNotificationManager's
+// notifier for n.AppID - the WinRT equivalent of the immediate (unscheduled) toast
+// PowerShellBackend's script shows. If n has a callback registered, Push blocks after
+// showing the toast until one of OnActivated/OnDismissed/OnFailed fires or
+// n.ActivationTimeout elapses, same as PowerShellBackend.
+//
+// ctx is checked only before the first WinRT call and, if n has a callback registered,
+// while waiting for one to fire: unlike PowerShellBackend, there's no child process to
+// kill, and the activation, LoadXml and Show calls in between aren't interrupted by a
+// cancellation.
+func (b WinRTBackend) Push(ctx context.Context, n *Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	procRoInitialize.Call(rtMultiThreaded)
+
+	xml, err := n.buildXML()
+	if err != nil {
+		return err
+	}
+
+	xmlDoc, err := activateInstance(classXmlDocument, ole.NewGUID(iidIXmlDocumentIO))
+	if err != nil {
+		return err
+	}
+	defer xmlDoc.Release()
+
+	xmlHandle, err := hstring(xml)
+	if err != nil {
+		return err
+	}
+	defer procWindowsDeleteString.Call(xmlHandle)
+
+	if err := callLoadXml(xmlDoc, xmlHandle); err != nil {
+		return err
+	}
+
+	managerFactory, err := activateInstance(classToastNotificationManager, ole.NewGUID(iidIToastNotificationManagerStatics))
+	if err != nil {
+		return err
+	}
+	defer managerFactory.Release()
+
+	appID, err := hstring(n.AppID)
+	if err != nil {
+		return err
+	}
+	defer procWindowsDeleteString.Call(appID)
+
+	notifier, err := callCreateToastNotifier(managerFactory, appID)
+	if err != nil {
+		return err
+	}
+	defer notifier.Release()
+
+	toastFactory, err := activateInstance(classToastNotification, ole.NewGUID(iidIToastNotificationFactory))
+	if err != nil {
+		return err
+	}
+	defer toastFactory.Release()
+
+	toast, err := callCreateToastNotification(toastFactory, xmlDoc)
+	if err != nil {
+		return err
+	}
+	defer toast.Release()
+
+	if !n.hasCallback() {
+		return callShow(notifier, toast)
+	}
+
+	done := make(chan struct{}, 1)
+	if err := subscribeToastEvents(toast, n, func() {
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		return err
+	}
+
+	if err := callShow(notifier, toast); err != nil {
+		return err
+	}
+
+	timeout := time.Duration(n.activationTimeoutSeconds() * float64(time.Second))
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// buildXML renders the same adaptive-toast XML fragment PowerShellBackend embeds in its
+// script, without the surrounding PowerShell.
+func (n *Notification) buildXML() (string, error) {
+	return buildToastXML(n)
+}
@@ -0,0 +1,31 @@
+package toast
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// xmlEscape escapes s for safe inclusion as XML character data or as the value of a
+// double-quoted XML attribute, used by toastXMLTemplate (the <toast> fragment shared by
+// every Backend) to guard every user-supplied field against breaking out of the toast XML.
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// validAppID reports whether s is safe to embed in the single-quoted PowerShell string
+// literal $APP_ID is assigned from, and matches the "no special characters" rule
+// documented on Notification.AppID.
+func validAppID(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+		switch r {
+		case '\'', '`', '\n', '\r':
+			return false
+		}
+	}
+	return true
+}
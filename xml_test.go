@@ -0,0 +1,34 @@
+package toast
+
+import "testing"
+
+func TestXmlEscape(t *testing.T) {
+	cases := map[string]string{
+		`<b>`:        "&lt;b&gt;",
+		`a & b`:      "a &amp; b",
+		`'quoted'`:   "&#39;quoted&#39;",
+		`"quoted"`:   "&#34;quoted&#34;",
+		`plain text`: "plain text",
+	}
+	for in, want := range cases {
+		if got := xmlEscape(in); got != want {
+			t.Errorf("xmlEscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestValidAppID(t *testing.T) {
+	cases := map[string]bool{
+		"My App":      true,
+		"":            true,
+		"it's mine":   false,
+		"back`tick":   false,
+		"line\nbreak": false,
+		"café":        false,
+	}
+	for in, want := range cases {
+		if got := validAppID(in); got != want {
+			t.Errorf("validAppID(%q) = %v, want %v", in, got, want)
+		}
+	}
+}